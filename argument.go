@@ -8,17 +8,19 @@ import (
 )
 
 type arg struct {
-	result   interface{} // Pointer to the resulting value
-	opts     *Options    // Options
-	sname    string      // Short name (in Parser will start with "-"
-	lname    string      // Long name (in Parser will start with "--"
-	size     int         // Size defines how many args after match will need to be consumed
-	unique   bool        // Specifies whether flag should be present only ones
-	parsed   bool        // Specifies whether flag has been parsed already
-	fileFlag int         // File mode to open file with
-	filePerm os.FileMode // File permissions to set a file
-	selector *[]string   // Used in Selector type to allow to choose only one from list of options
-	parent   *Command    // Used to get access to specific Command
+	result     interface{} // Pointer to the resulting value
+	opts       *Options    // Options
+	sname      string      // Short name (in Parser will start with "-"
+	lname      string      // Long name (in Parser will start with "--"
+	size       int         // Size defines how many args after match will need to be consumed
+	unique     bool        // Specifies whether flag should be present only ones
+	parsed     bool        // Specifies whether flag has been parsed already
+	fileFlag   int         // File mode to open file with
+	filePerm   os.FileMode // File permissions to set a file
+	selector   *[]string   // Used in Selector type to allow to choose only one from list of options
+	parent     *Command    // Used to get access to specific Command
+	positional bool        // Specifies whether this arg is matched by position rather than by name
+	varname    string      // Display name used in place of sname/lname for a positional arg
 }
 
 // Arg interface provides exporting of arg structure, while exposing it
@@ -82,6 +84,37 @@ func (o *arg) check(argument string) int {
 	return 0
 }
 
+// assignedValue looks for a GNU-style inline value attached to argument, such
+// as "--long=value", "-sVALUE" or "-s=value", and reports whether argument
+// matches this arg's name in that form. Args with size 1 (Flag, FlagCounter)
+// never take an inline value: a short name there is matched and grouped like
+// "-abc" instead, so an attached "=value" on one (e.g. "-vvv=3") is an error
+// rather than being silently swallowed.
+func (o *arg) assignedValue(argument string) (value string, matched bool, err error) {
+	if o.lname != "" && len(argument) > 2 && strings.HasPrefix(argument, "--") && argument[2] != '-' {
+		name, val, hasEq := strings.Cut(argument[2:], "=")
+		if hasEq && name == o.lname {
+			if o.size == 1 {
+				return "", true, fmt.Errorf("[%s] does not take a value", o.name())
+			}
+			return val, true, nil
+		}
+	}
+	if o.sname != "" && len(argument) > 1 && strings.HasPrefix(argument, "-") && argument[1] != '-' {
+		body := argument[1:]
+		if o.size == 1 {
+			if strings.Contains(body, o.sname) && strings.Contains(body, "=") {
+				return "", true, fmt.Errorf("[%s] does not take a value", o.name())
+			}
+			return "", false, nil
+		}
+		if strings.HasPrefix(body, o.sname) && body != o.sname {
+			return strings.TrimPrefix(body[len(o.sname):], "="), true, nil
+		}
+	}
+	return "", false, nil
+}
+
 func (o *arg) reduce(position int, args *[]string) {
 	argument := (*args)[position]
 	// Check for long name only if not empty
@@ -133,11 +166,24 @@ func (o *arg) parse(args []string, argCount int) error {
 		}
 	}
 
-	switch o.result.(type) {
+	switch r := o.result.(type) {
 	case *help:
 		helpText := o.parent.Help(nil)
 		fmt.Print(helpText)
 		os.Exit(0)
+	// A custom Value registered via Command.Var takes priority over the
+	// built-in types below, since Value itself is never one of them.
+	case Value:
+		if len(args) < 1 {
+			return fmt.Errorf("[%s] must be followed by a %s", o.name(), r.Type())
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("[%s] followed by too many arguments", o.name())
+		}
+		if err := r.Set(args[0]); err != nil {
+			return fmt.Errorf("[%s] %v", o.name(), err)
+		}
+		o.parsed = true
 		//data of bool type is for Flag argument
 	case *bool:
 		*o.result.(*bool) = true
@@ -284,6 +330,9 @@ func (o *arg) parse(args []string, argCount int) error {
 
 func (o *arg) name() string {
 	var name string
+	if o.positional {
+		return o.varname
+	}
 	if o.lname == "" {
 		name = "-" + o.sname
 	} else if o.sname == "" {
@@ -297,7 +346,15 @@ func (o *arg) name() string {
 func (o *arg) usage() string {
 	var result string
 	result = o.name()
-	switch o.result.(type) {
+	if o.positional {
+		if _, ok := o.result.(*[]string); ok {
+			result = result + "..."
+		}
+		return result
+	}
+	switch r := o.result.(type) {
+	case Value:
+		result = result + " <" + r.Type() + ">"
 	case *bool:
 		break
 	case *int:
@@ -317,13 +374,18 @@ func (o *arg) usage() string {
 	default:
 		break
 	}
-	if o.opts == nil || o.opts.Required == false {
+	// Positional args are rendered bare, with no dashes or optionality brackets;
+	// their own varname/"..." suffix already conveys arity.
+	if !o.positional && (o.opts == nil || o.opts.Required == false) {
 		result = "[" + result + "]"
 	}
 	return result
 }
 
 func (o *arg) getHelpMessage() string {
+	if o.opts == nil {
+		return ""
+	}
 	message := ""
 	if len(o.opts.Help) > 0 {
 		message += o.opts.Help
@@ -331,89 +393,285 @@ func (o *arg) getHelpMessage() string {
 			message += fmt.Sprintf(". Default: %v", o.opts.Default)
 		}
 	}
+	if o.opts.EnvVar != "" {
+		message += fmt.Sprintf(" [env: %s]", o.opts.EnvVar)
+	}
 	return message
 }
 
+// setDefault fills in a value for an arg that was not present on the command
+// line. The resolution order is: env var, then config-file value, then
+// Options.Default; the first source that is set wins. The config-file lookup
+// falls back to the arg's long name when Options (or Options.ConfigKey) is
+// unset, so it applies even to args registered with a nil *Options.
 func (o *arg) setDefault() error {
-	// Only set default if it was not parsed, and default value was defined
-	if !o.parsed && o.opts != nil && o.opts.Default != nil {
-		switch o.result.(type) {
-		case *bool:
-			if _, ok := o.opts.Default.(bool); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [bool]", o.opts.Default)
-			}
-			*o.result.(*bool) = o.opts.Default.(bool)
-		case *int:
-			if _, ok := o.opts.Default.(int); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [int]", o.opts.Default)
-			}
-			*o.result.(*int) = o.opts.Default.(int)
-		case *float64:
-			if _, ok := o.opts.Default.(float64); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [float64]", o.opts.Default)
+	if o.parsed {
+		return nil
+	}
+
+	if o.opts != nil && o.opts.EnvVar != "" {
+		if raw, ok := os.LookupEnv(o.opts.EnvVar); ok {
+			value, err := o.envValue(raw)
+			if err != nil {
+				return err
 			}
-			*o.result.(*float64) = o.opts.Default.(float64)
-		case *string:
-			if _, ok := o.opts.Default.(string); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [string]", o.opts.Default)
+			return o.assign(value)
+		}
+	}
+
+	key := ""
+	if o.opts != nil {
+		key = o.opts.ConfigKey
+	}
+	if key == "" {
+		key = o.lname
+	}
+	if key != "" && o.parent != nil {
+		if value, ok := o.parent.configValue(key); ok {
+			return o.assign(value)
+		}
+	}
+
+	if o.opts != nil && o.opts.Default != nil {
+		return o.assign(o.opts.Default)
+	}
+
+	return nil
+}
+
+// envValue converts the raw string value of an env var into the Go type
+// expected by this arg's result, splitting list-typed args on EnvSeparator
+// (or os.PathListSeparator if it is unset).
+func (o *arg) envValue(raw string) (interface{}, error) {
+	sep := string(os.PathListSeparator)
+	if o.opts.EnvSeparator != "" {
+		sep = o.opts.EnvSeparator
+	}
+
+	switch o.result.(type) {
+	case Value:
+		return raw, nil
+	case *bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] bad boolean value [%s] in env var %s", o.name(), raw, o.opts.EnvVar)
+		}
+		return v, nil
+	case *int:
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] bad integer value [%s] in env var %s", o.name(), raw, o.opts.EnvVar)
+		}
+		return v, nil
+	case *float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] bad floating point value [%s] in env var %s", o.name(), raw, o.opts.EnvVar)
+		}
+		return v, nil
+	case *string, *os.File:
+		return raw, nil
+	case *[]string, *[]os.File:
+		return strings.Split(raw, sep), nil
+	case *[]int:
+		parts := strings.Split(raw, sep)
+		vals := make([]int, 0, len(parts))
+		for _, p := range parts {
+			v, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, fmt.Errorf("[%s] bad integer value [%s] in env var %s", o.name(), p, o.opts.EnvVar)
 			}
-			*o.result.(*string) = o.opts.Default.(string)
-		case *os.File:
-			// In case of File we should get string as default value
-			if v, ok := o.opts.Default.(string); ok {
-				f, err := os.OpenFile(v, o.fileFlag, o.filePerm)
-				if err != nil {
-					return err
-				}
-				*o.result.(*os.File) = *f
-			} else {
-				return fmt.Errorf("cannot use default type [%T] as type [string]", o.opts.Default)
+			vals = append(vals, v)
+		}
+		return vals, nil
+	case *[]float64:
+		parts := strings.Split(raw, sep)
+		vals := make([]float64, 0, len(parts))
+		for _, p := range parts {
+			v, err := strconv.ParseFloat(p, 64)
+			if err != nil {
+				return nil, fmt.Errorf("[%s] bad floating point value [%s] in env var %s", o.name(), p, o.opts.EnvVar)
 			}
-		case *[]string:
-			if _, ok := o.opts.Default.([]string); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [[]string]", o.opts.Default)
+			vals = append(vals, v)
+		}
+		return vals, nil
+	default:
+		return nil, fmt.Errorf("[%s] unsupported type [%T] for env var %s", o.name(), o.result, o.opts.EnvVar)
+	}
+}
+
+// toInt accepts an int as-is, or a float64 (as produced by encoding/json for
+// any JSON number) truncated towards zero.
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// toStringSlice accepts a []string as-is, or a []interface{} of strings (as
+// produced by encoding/json for a JSON array) converted element-wise.
+func toStringSlice(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
 			}
-			*o.result.(*[]string) = o.opts.Default.([]string)
-		case *[]int:
-			if _, ok := o.opts.Default.([]int); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [[]int]", o.opts.Default)
+			result = append(result, s)
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// toIntSlice accepts a []int as-is, or a []interface{} of ints/float64s (as
+// produced by encoding/json for a JSON array of numbers) converted element-wise.
+func toIntSlice(value interface{}) ([]int, bool) {
+	switch v := value.(type) {
+	case []int:
+		return v, true
+	case []interface{}:
+		result := make([]int, 0, len(v))
+		for _, e := range v {
+			n, ok := toInt(e)
+			if !ok {
+				return nil, false
 			}
-			*o.result.(*[]int) = o.opts.Default.([]int)
-		case *[]float64:
-			if _, ok := o.opts.Default.([]float64); !ok {
-				return fmt.Errorf("cannot use default type [%T] as type [[]float64]", o.opts.Default)
+			result = append(result, n)
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// toFloat64Slice accepts a []float64 as-is, or a []interface{} of
+// ints/float64s (as produced by encoding/json for a JSON array of numbers)
+// converted element-wise.
+func toFloat64Slice(value interface{}) ([]float64, bool) {
+	switch v := value.(type) {
+	case []float64:
+		return v, true
+	case []interface{}:
+		result := make([]float64, 0, len(v))
+		for _, e := range v {
+			switch n := e.(type) {
+			case float64:
+				result = append(result, n)
+			case int:
+				result = append(result, float64(n))
+			default:
+				return nil, false
 			}
-			*o.result.(*[]float64) = o.opts.Default.([]float64)
-		case *[]os.File:
-			// In case of FileList we should get []string as default value
-			var files []os.File
-			if fileNames, ok := o.opts.Default.([]string); ok {
-				files = make([]os.File, 0, len(fileNames))
-				for _, v := range fileNames {
-					f, err := os.OpenFile(v, o.fileFlag, o.filePerm)
-					if err != nil {
-						//if one of FileList's file opening have been failed, close all other in this list
-						errs := make([]string, 0, len(*o.result.(*[]os.File)))
-						for _, f := range *o.result.(*[]os.File) {
-							if err := f.Close(); err != nil {
-								//almost unreal, but what if another process closed this file
-								errs = append(errs, err.Error())
-							}
-						}
-						if len(errs) > 0 {
-							err = fmt.Errorf("while handling error: %v, other errors occured: %#v", err.Error(), errs)
-						}
-						*o.result.(*[]os.File) = []os.File{}
-						return err
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// assign stores value into o.result, type-asserting it against the concrete
+// type o.result points to. It backs setDefault's env var, config-file and
+// Options.Default resolution, and opens os.File/[]os.File values from their
+// string path(s) using o.fileFlag/o.filePerm just as parse does. Since a
+// config file loaded through LoadConfig with encoding/json decodes numbers as
+// float64 and arrays as []interface{}, the int/[]int/[]float64/[]string
+// cases also accept those JSON-native shapes via toInt/toIntSlice/
+// toFloat64Slice/toStringSlice.
+func (o *arg) assign(value interface{}) error {
+	switch r := o.result.(type) {
+	case Value:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [%s] for [%s]", value, r.Type(), o.name())
+		}
+		if err := r.Set(s); err != nil {
+			return fmt.Errorf("[%s] %v", o.name(), err)
+		}
+	case *bool:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [bool] for [%s]", value, o.name())
+		}
+		*o.result.(*bool) = v
+	case *int:
+		v, ok := toInt(value)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [int] for [%s]", value, o.name())
+		}
+		*o.result.(*int) = v
+	case *float64:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [float64] for [%s]", value, o.name())
+		}
+		*o.result.(*float64) = v
+	case *string:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [string] for [%s]", value, o.name())
+		}
+		*o.result.(*string) = v
+	case *os.File:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [string] for [%s]", value, o.name())
+		}
+		f, err := os.OpenFile(v, o.fileFlag, o.filePerm)
+		if err != nil {
+			return err
+		}
+		*o.result.(*os.File) = *f
+	case *[]string:
+		v, ok := toStringSlice(value)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [[]string] for [%s]", value, o.name())
+		}
+		*o.result.(*[]string) = v
+	case *[]int:
+		v, ok := toIntSlice(value)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [[]int] for [%s]", value, o.name())
+		}
+		*o.result.(*[]int) = v
+	case *[]float64:
+		v, ok := toFloat64Slice(value)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [[]float64] for [%s]", value, o.name())
+		}
+		*o.result.(*[]float64) = v
+	case *[]os.File:
+		fileNames, ok := toStringSlice(value)
+		if !ok {
+			return fmt.Errorf("cannot use value type [%T] as type [[]string] for [%s]", value, o.name())
+		}
+		files := make([]os.File, 0, len(fileNames))
+		for _, v := range fileNames {
+			f, err := os.OpenFile(v, o.fileFlag, o.filePerm)
+			if err != nil {
+				//if one of FileList's file opening have been failed, close all other in this list
+				errs := make([]string, 0, len(*o.result.(*[]os.File)))
+				for _, f := range *o.result.(*[]os.File) {
+					if err := f.Close(); err != nil {
+						//almost unreal, but what if another process closed this file
+						errs = append(errs, err.Error())
 					}
-					files = append(files, *f)
 				}
-			} else {
-				return fmt.Errorf("cannot use default type [%T] as type [[]string]", o.opts.Default)
+				if len(errs) > 0 {
+					err = fmt.Errorf("while handling error: %v, other errors occured: %#v", err.Error(), errs)
+				}
+				*o.result.(*[]os.File) = []os.File{}
+				return err
 			}
-			*o.result.(*[]os.File) = files
+			files = append(files, *f)
 		}
+		*o.result.(*[]os.File) = files
 	}
-
 	return nil
 }
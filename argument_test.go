@@ -0,0 +1,49 @@
+package argparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileFlagInlineValue covers the GNU-style inline forms added to
+// assignedValue: "--long=value", "-s=value" and the bare "-svalue" attachment.
+func TestFileFlagInlineValue(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		name string
+		arg  func(path string) string
+	}{
+		{"long name with equals", func(path string) string { return "--output=" + path }},
+		{"short name with equals", func(path string) string { return "-o=" + path }},
+		{"short name attached", func(path string) string { return "-o" + path }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(dir, "out.txt")
+			p := NewParser("test", "")
+			out, _ := p.File("o", "output", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644, nil)
+
+			if err := p.Parse([]string{c.arg(path)}); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if out.Name() != path {
+				t.Errorf("out.Name() = %q, want %q", out.Name(), path)
+			}
+		})
+	}
+}
+
+// TestFlagCounterRejectsInlineValue covers assignedValue's rule that size-1
+// args (Flag, FlagCounter) never take an inline value, since their short name
+// is instead matched and grouped like "-vvv".
+func TestFlagCounterRejectsInlineValue(t *testing.T) {
+	p := NewParser("test", "")
+	_, _ = p.FlagCounter("v", "verbose", nil)
+
+	if err := p.Parse([]string{"-vvv=3"}); err == nil {
+		t.Fatal("Parse() error = nil, want error for inline value on a FlagCounter")
+	}
+}
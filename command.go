@@ -0,0 +1,130 @@
+package argparse
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Command represents a named collection of arguments and subcommands. The
+// root Command returned by NewParser is commonly referred to as the Parser.
+type Command struct {
+	name        string
+	description string
+	args        []*arg
+	commands    map[string]*Command
+	parent      *Command
+
+	positional     []*arg                 // fixed positional args, matched in registration order
+	positionalList *arg                   // optional trailing variadic positional
+	remaining      *[]string              // optional RemainingArgs target
+	argv           []string               // positional tokens seen by the most recent Parse
+	groups         []*Group               // required/mutually-exclusive/dependent arg groups
+	config         map[string]interface{} // values loaded via LoadConfig, keyed by Options.ConfigKey
+}
+
+// Parser is the root Command of an application.
+type Parser = Command
+
+// NewParser creates the root Command used to register arguments and
+// subcommands, and to parse command line arguments against them.
+func NewParser(name string, description string) *Parser {
+	return &Parser{
+		name:        name,
+		description: description,
+		args:        make([]*arg, 0),
+		commands:    make(map[string]*Command),
+	}
+}
+
+// Command registers and returns a new subcommand of this Command.
+func (o *Command) Command(name string, description string) *Command {
+	c := &Command{
+		name:        name,
+		description: description,
+		args:        make([]*arg, 0),
+		commands:    make(map[string]*Command),
+		parent:      o,
+	}
+	o.commands[name] = c
+	return c
+}
+
+// newArg registers an arg backed by result on this Command.
+func (o *Command) newArg(result interface{}, sname string, lname string, size int, unique bool, opts *Options) *arg {
+	a := &arg{
+		result: result,
+		opts:   opts,
+		sname:  sname,
+		lname:  lname,
+		size:   size,
+		unique: unique,
+		parent: o,
+	}
+	o.args = append(o.args, a)
+	return a
+}
+
+// Help renders the usage text for this Command, optionally prefixed with err
+// when it is non-nil.
+func (o *Command) Help(err error) string {
+	var b strings.Builder
+	if err != nil {
+		fmt.Fprintf(&b, "%s\n\n", err.Error())
+	}
+	groupOf := make(map[*arg]*Group, len(o.args))
+	for _, g := range o.groups {
+		for _, a := range g.members {
+			if underlying, ok := a.(*arg); ok {
+				groupOf[underlying] = g
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "usage: %s", o.name)
+	printed := make(map[*Group]bool, len(o.groups))
+	for _, a := range o.args {
+		if g, ok := groupOf[a]; ok {
+			if printed[g] {
+				continue
+			}
+			printed[g] = true
+			fmt.Fprintf(&b, " %s", g.usage())
+			continue
+		}
+		fmt.Fprintf(&b, " %s", a.usage())
+	}
+	b.WriteString("\n")
+	for _, a := range o.args {
+		if msg := a.getHelpMessage(); msg != "" {
+			fmt.Fprintf(&b, "  %s\t%s\n", a.name(), msg)
+		}
+	}
+	return b.String()
+}
+
+// LoadConfig reads path and decodes it with unmarshal (typically
+// json.Unmarshal, or a YAML package's equivalent) into this Command's config,
+// used by Options.ConfigKey as a default source that ranks below an env var
+// but above Options.Default.
+func (o *Command) LoadConfig(path string, unmarshal func([]byte, interface{}) error) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	config := make(map[string]interface{})
+	if err := unmarshal(data, &config); err != nil {
+		return err
+	}
+	o.config = config
+	return nil
+}
+
+// configValue looks up key in this Command's loaded config.
+func (o *Command) configValue(key string) (interface{}, bool) {
+	if o.config == nil {
+		return nil, false
+	}
+	v, ok := o.config[key]
+	return v, ok
+}
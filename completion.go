@@ -0,0 +1,303 @@
+package argparse
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// completeFlagName is the hidden flag generated completion scripts use to
+// call back into the binary for dynamic completions, bypassing normal
+// argument parsing. See Command.Parse and Options.Complete.
+const completeFlagName = "--__complete"
+
+// CompletionScript renders a shell completion script for this Parser. shell
+// must be one of "bash", "zsh" or "fish".
+func (o *Parser) CompletionScript(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return o.bashCompletion(), nil
+	case "zsh":
+		return o.zshCompletion(), nil
+	case "fish":
+		return o.fishCompletion(), nil
+	default:
+		return "", fmt.Errorf("unsupported shell [%s]", shell)
+	}
+}
+
+// handleComplete serves the hidden completeFlagName callback: args is
+// [flagName, prefix]. The matching arg's Options.Complete hook, if any, is
+// invoked and its suggestions are printed one per line for the shell wrapper
+// to collect.
+func (o *Command) handleComplete(args []string) {
+	if len(args) < 2 {
+		return
+	}
+	flagName, prefix := args[0], args[1]
+	for _, a := range o.args {
+		if a.opts == nil || a.opts.Complete == nil {
+			continue
+		}
+		if (a.lname != "" && "--"+a.lname == flagName) || (a.sname != "" && "-"+a.sname == flagName) {
+			for _, s := range a.opts.Complete(prefix) {
+				fmt.Println(s)
+			}
+			return
+		}
+	}
+}
+
+// flagNames lists every named (non-positional) flag on this Command in both
+// of its forms, e.g. "--output" and "-o".
+func (o *Command) flagNames() []string {
+	names := make([]string, 0, len(o.args)*2)
+	for _, a := range o.args {
+		if a.positional {
+			continue
+		}
+		if a.lname != "" {
+			names = append(names, "--"+a.lname)
+		}
+		if a.sname != "" {
+			names = append(names, "-"+a.sname)
+		}
+	}
+	return names
+}
+
+// subcommandNames lists this Command's registered subcommands in sorted order.
+func (o *Command) subcommandNames() []string {
+	names := make([]string, 0, len(o.commands))
+	for name := range o.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fileFlags lists the flag forms of every File/FileList arg. Both read
+// (no O_CREATE) and write (O_CREATE) paths get the same path completion: the
+// shells' own file-completion primitives (bash's "compgen -f", zsh's
+// "_files", fish's "-F") all complete both existing files and directories to
+// descend into, so a write path that doesn't exist yet completes exactly as
+// well as a read path that does.
+func (o *Command) fileFlags() []string {
+	var names []string
+	for _, a := range o.args {
+		switch a.result.(type) {
+		case *os.File, *[]os.File:
+		default:
+			continue
+		}
+		if a.lname != "" {
+			names = append(names, "--"+a.lname)
+		}
+		if a.sname != "" {
+			names = append(names, "-"+a.sname)
+		}
+	}
+	return names
+}
+
+type flagChoices struct {
+	flag    string
+	choices []string
+}
+
+// selectorFlags lists the flag forms of every Selector arg alongside its
+// allowed values.
+func (o *Command) selectorFlags() []flagChoices {
+	var result []flagChoices
+	for _, a := range o.args {
+		if a.selector == nil {
+			continue
+		}
+		if a.lname != "" {
+			result = append(result, flagChoices{"--" + a.lname, *a.selector})
+		}
+		if a.sname != "" {
+			result = append(result, flagChoices{"-" + a.sname, *a.selector})
+		}
+	}
+	return result
+}
+
+// dynamicFlags lists the flag forms of every arg with an Options.Complete hook.
+func (o *Command) dynamicFlags() []string {
+	var names []string
+	for _, a := range o.args {
+		if a.opts == nil || a.opts.Complete == nil {
+			continue
+		}
+		if a.lname != "" {
+			names = append(names, "--"+a.lname)
+		}
+		if a.sname != "" {
+			names = append(names, "-"+a.sname)
+		}
+	}
+	return names
+}
+
+// completionFlagName returns the flag form passed to completeFlagName
+// callbacks and generated zsh/fish scripts, preferring the long name.
+func (o *arg) completionFlagName() string {
+	if o.lname != "" {
+		return "--" + o.lname
+	}
+	return "-" + o.sname
+}
+
+// sanitize turns name into a valid shell function name fragment.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (o *Command) bashCompletion() string {
+	var b strings.Builder
+	fnName := "_" + sanitize(o.name)
+
+	fmt.Fprintf(&b, "# bash completion for %s\n", o.name)
+	fmt.Fprintf(&b, "%s() {\n", fnName)
+	b.WriteString("  local cur prev\n")
+	b.WriteString("  COMPREPLY=()\n")
+	b.WriteString("  cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("  prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+
+	if dyn := o.dynamicFlags(); len(dyn) > 0 {
+		fmt.Fprintf(&b, "  case \"$prev\" in\n    %s)\n", strings.Join(dyn, "|"))
+		fmt.Fprintf(&b, "      COMPREPLY=( $(compgen -W \"$(%s %s \"$prev\" \"$cur\")\" -- \"$cur\") )\n", o.name, completeFlagName)
+		b.WriteString("      return 0\n      ;;\n  esac\n\n")
+	}
+
+	if files := o.fileFlags(); len(files) > 0 {
+		fmt.Fprintf(&b, "  case \"$prev\" in\n    %s)\n", strings.Join(files, "|"))
+		b.WriteString("      COMPREPLY=( $(compgen -f -- \"$cur\") )\n")
+		b.WriteString("      return 0\n      ;;\n  esac\n\n")
+	}
+
+	for _, fc := range o.selectorFlags() {
+		fmt.Fprintf(&b, "  if [[ \"$prev\" == \"%s\" ]]; then\n", fc.flag)
+		fmt.Fprintf(&b, "    COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", strings.Join(fc.choices, " "))
+		b.WriteString("    return 0\n  fi\n\n")
+	}
+
+	opts := append(o.subcommandNames(), o.flagNames()...)
+	fmt.Fprintf(&b, "  COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", strings.Join(opts, " "))
+	b.WriteString("  return 0\n}\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fnName, o.name)
+	return b.String()
+}
+
+func (o *Command) zshCompletion() string {
+	var b strings.Builder
+	fnName := "_" + sanitize(o.name)
+
+	fileFlagSet := make(map[string]bool)
+	for _, flag := range o.fileFlags() {
+		fileFlagSet[flag] = true
+	}
+	selectorChoices := make(map[string][]string)
+	for _, fc := range o.selectorFlags() {
+		selectorChoices[fc.flag] = fc.choices
+	}
+	dyn := o.dynamicFlags()
+	dynState := make(map[string]bool, len(dyn))
+	for _, flag := range dyn {
+		dynState[flag] = true
+	}
+
+	fmt.Fprintf(&b, "#compdef %s\n\n", o.name)
+	fmt.Fprintf(&b, "%s() {\n", fnName)
+	b.WriteString("  local context state state_descr line\n")
+	b.WriteString("  local -a specs\n")
+	b.WriteString("  specs=(\n")
+	for _, a := range o.args {
+		if a.positional {
+			continue
+		}
+		flag := a.completionFlagName()
+		help := ""
+		if a.opts != nil {
+			help = strings.ReplaceAll(a.opts.Help, "'", "''")
+		}
+		switch {
+		case dynState[flag]:
+			fmt.Fprintf(&b, "    '%s[%s]:value:->%s'\n", flag, help, sanitize(flag))
+		case len(selectorChoices[flag]) > 0:
+			fmt.Fprintf(&b, "    '%s[%s]:value:(%s)'\n", flag, help, strings.Join(selectorChoices[flag], " "))
+		case fileFlagSet[flag]:
+			fmt.Fprintf(&b, "    '%s[%s]:file:_files'\n", flag, help)
+		default:
+			fmt.Fprintf(&b, "    '%s[%s]'\n", flag, help)
+		}
+	}
+	for _, name := range o.subcommandNames() {
+		fmt.Fprintf(&b, "    '%s:%s'\n", name, name)
+	}
+	b.WriteString("  )\n")
+	b.WriteString("  _arguments -s $specs\n")
+
+	if len(dyn) > 0 {
+		b.WriteString("\n  case \"$state\" in\n")
+		for _, flag := range dyn {
+			fmt.Fprintf(&b, "    %s)\n", sanitize(flag))
+			b.WriteString("      local -a choices\n")
+			fmt.Fprintf(&b, "      choices=(${(f)\"$(%s %s %s \"$PREFIX\")\"})\n", o.name, completeFlagName, flag)
+			b.WriteString("      _describe 'value' choices\n")
+			b.WriteString("      ;;\n")
+		}
+		b.WriteString("  esac\n")
+	}
+
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "%s \"$@\"\n", fnName)
+	return b.String()
+}
+
+func (o *Command) fishCompletion() string {
+	var b strings.Builder
+
+	fileFlagSet := make(map[string]bool)
+	for _, flag := range o.fileFlags() {
+		fileFlagSet[flag] = true
+	}
+
+	for _, name := range o.subcommandNames() {
+		fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a '%s'\n", o.name, name)
+	}
+
+	for _, a := range o.args {
+		if a.positional {
+			continue
+		}
+		fmt.Fprintf(&b, "complete -c %s", o.name)
+		if a.lname != "" {
+			fmt.Fprintf(&b, " -l %s", a.lname)
+		}
+		if a.sname != "" {
+			fmt.Fprintf(&b, " -s %s", a.sname)
+		}
+		if a.opts != nil && a.opts.Help != "" {
+			fmt.Fprintf(&b, " -d '%s'", strings.ReplaceAll(a.opts.Help, "'", "\\'"))
+		}
+		flag := a.completionFlagName()
+		switch {
+		case a.opts != nil && a.opts.Complete != nil:
+			fmt.Fprintf(&b, " -r -a '(%s %s %s (commandline -ct))'", o.name, completeFlagName, flag)
+		case a.selector != nil:
+			fmt.Fprintf(&b, " -r -a '%s'", strings.Join(*a.selector, " "))
+		case fileFlagSet[flag]:
+			b.WriteString(" -r -F")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package argparse
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestCompletionScriptSelectorAndFile checks that each shell's generated
+// script renders a selector flag's choices and offers file completion for a
+// File flag.
+func TestCompletionScriptSelectorAndFile(t *testing.T) {
+	fileCompletion := map[string]string{
+		"bash": "compgen -f",
+		"zsh":  "--output[]:file:_files",
+		"fish": "-l output -s o -r -F",
+	}
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			p := NewParser("test", "")
+			p.Selector("m", "mode", []string{"fast", "slow"}, nil)
+			p.File("o", "output", os.O_CREATE|os.O_WRONLY, 0644, nil)
+
+			script, err := p.CompletionScript(shell)
+			if err != nil {
+				t.Fatalf("CompletionScript() error = %v", err)
+			}
+			if !strings.Contains(script, "fast") || !strings.Contains(script, "slow") {
+				t.Errorf("%s script missing selector choices:\n%s", shell, script)
+			}
+			if !strings.Contains(script, "output") {
+				t.Errorf("%s script missing output flag:\n%s", shell, script)
+			}
+			if !strings.Contains(script, fileCompletion[shell]) {
+				t.Errorf("%s script missing file completion %q for output flag:\n%s", shell, fileCompletion[shell], script)
+			}
+		})
+	}
+}
+
+// TestCompletionScriptUnsupportedShell covers the error path for an unknown shell.
+func TestCompletionScriptUnsupportedShell(t *testing.T) {
+	p := NewParser("test", "")
+	if _, err := p.CompletionScript("tcsh"); err == nil {
+		t.Fatal("CompletionScript() error = nil, want error for unsupported shell")
+	}
+}
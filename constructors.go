@@ -0,0 +1,108 @@
+package argparse
+
+import "os"
+
+// Flag registers a boolean switch argument. Multiple size-1 flags may be
+// grouped in one token, e.g. "-abc" for three separate Flag/FlagCounter args.
+// The returned Arg is the handle used to enroll this argument in a Group.
+func (o *Command) Flag(sname string, lname string, opts *Options) (*bool, Arg) {
+	var result bool
+	a := o.newArg(&result, sname, lname, 1, true, opts)
+	return &result, a
+}
+
+// FlagCounter registers an argument whose value is the number of times it
+// was present on the command line, e.g. "-vvv" for 3. The returned Arg is the
+// handle used to enroll this argument in a Group.
+func (o *Command) FlagCounter(sname string, lname string, opts *Options) (*int, Arg) {
+	var result int
+	a := o.newArg(&result, sname, lname, 1, false, opts)
+	return &result, a
+}
+
+// Int registers an argument whose single following value is parsed as an
+// integer. The returned Arg is the handle used to enroll this argument in a
+// Group.
+func (o *Command) Int(sname string, lname string, opts *Options) (*int, Arg) {
+	var result int
+	a := o.newArg(&result, sname, lname, 2, true, opts)
+	return &result, a
+}
+
+// Float64 registers an argument whose single following value is parsed as a
+// floating point number. The returned Arg is the handle used to enroll this
+// argument in a Group.
+func (o *Command) Float64(sname string, lname string, opts *Options) (*float64, Arg) {
+	var result float64
+	a := o.newArg(&result, sname, lname, 2, true, opts)
+	return &result, a
+}
+
+// String registers an argument whose single following value is taken as-is.
+// The returned Arg is the handle used to enroll this argument in a Group.
+func (o *Command) String(sname string, lname string, opts *Options) (*string, Arg) {
+	var result string
+	a := o.newArg(&result, sname, lname, 2, true, opts)
+	return &result, a
+}
+
+// Selector registers a String argument whose value is restricted to one of
+// options. The returned Arg is the handle used to enroll this argument in a
+// Group.
+func (o *Command) Selector(sname string, lname string, options []string, opts *Options) (*string, Arg) {
+	var result string
+	a := o.newArg(&result, sname, lname, 2, true, opts)
+	a.selector = &options
+	return &result, a
+}
+
+// File registers an argument whose single following value is a path, opened
+// with fileFlag/filePerm via os.OpenFile. The returned Arg is the handle used
+// to enroll this argument in a Group.
+func (o *Command) File(sname string, lname string, fileFlag int, filePerm os.FileMode, opts *Options) (*os.File, Arg) {
+	var result os.File
+	a := o.newArg(&result, sname, lname, 2, true, opts)
+	a.fileFlag = fileFlag
+	a.filePerm = filePerm
+	return &result, a
+}
+
+// StringList registers an argument that may be present multiple times,
+// appending each following value to the result in order. The returned Arg is
+// the handle used to enroll this argument in a Group.
+func (o *Command) StringList(sname string, lname string, opts *Options) (*[]string, Arg) {
+	result := make([]string, 0)
+	a := o.newArg(&result, sname, lname, 2, false, opts)
+	return &result, a
+}
+
+// IntList registers an argument that may be present multiple times,
+// appending each following value, parsed as an integer, to the result in
+// order. The returned Arg is the handle used to enroll this argument in a
+// Group.
+func (o *Command) IntList(sname string, lname string, opts *Options) (*[]int, Arg) {
+	result := make([]int, 0)
+	a := o.newArg(&result, sname, lname, 2, false, opts)
+	return &result, a
+}
+
+// FloatList registers an argument that may be present multiple times,
+// appending each following value, parsed as a float, to the result in order.
+// The returned Arg is the handle used to enroll this argument in a Group.
+func (o *Command) FloatList(sname string, lname string, opts *Options) (*[]float64, Arg) {
+	result := make([]float64, 0)
+	a := o.newArg(&result, sname, lname, 2, false, opts)
+	return &result, a
+}
+
+// FileList registers an argument that may be present multiple times, opening
+// each following value as a path with fileFlag/filePerm and appending it to
+// the result in order. The returned Arg is the handle used to enroll this
+// argument in a Group.
+func (o *Command) FileList(sname string, lname string, fileFlag int, filePerm os.FileMode, opts *Options) (*[]os.File, Arg) {
+	result := make([]os.File, 0)
+	a := o.newArg(&result, sname, lname, 2, false, opts)
+	a.fileFlag = fileFlag
+	a.filePerm = filePerm
+	return &result, a
+}
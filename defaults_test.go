@@ -0,0 +1,72 @@
+package argparse
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEnvVarOverridesListDefaultWithCustomSeparator covers an env var
+// supplying a list-typed arg's value, split on a custom EnvSeparator, taking
+// priority over Options.Default.
+func TestEnvVarOverridesListDefaultWithCustomSeparator(t *testing.T) {
+	const envVar = "ARGPARSE_TEST_TAGS"
+	t.Setenv(envVar, "a,b,c")
+
+	p := NewParser("test", "")
+	tags, _ := p.StringList("t", "tags", &Options{
+		EnvVar:       envVar,
+		EnvSeparator: ",",
+		Default:      []string{"default"},
+	})
+
+	if err := p.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(*tags) != len(want) {
+		t.Fatalf("*tags = %v, want %v", *tags, want)
+	}
+	for i, v := range want {
+		if (*tags)[i] != v {
+			t.Errorf("*tags = %v, want %v", *tags, want)
+			break
+		}
+	}
+}
+
+// TestConfigValueCoercion covers that a config file loaded via LoadConfig,
+// which encoding/json decodes numbers as float64 and arrays as
+// []interface{}, is coerced into an *int's and *[]string's native Go types.
+func TestConfigValueCoercion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	data, err := json.Marshal(map[string]interface{}{
+		"count": 5,
+		"tags":  []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	p := NewParser("test", "")
+	if err := p.LoadConfig(path, json.Unmarshal); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	count, _ := p.Int("c", "count", nil)
+	tags, _ := p.StringList("t", "tags", nil)
+
+	if err := p.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if *count != 5 {
+		t.Errorf("*count = %d, want 5", *count)
+	}
+	if len(*tags) != 2 || (*tags)[0] != "a" || (*tags)[1] != "b" {
+		t.Errorf("*tags = %v, want [a b]", *tags)
+	}
+}
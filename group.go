@@ -0,0 +1,129 @@
+package argparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupOptions configures the constraints enforced by a Group.
+type GroupOptions struct {
+	Required          bool // at least one member of the group must be parsed
+	MutuallyExclusive bool // at most one member of the group may be parsed
+}
+
+// Group constrains how a set of previously created Args relate to one
+// another: whether at least one must be present, whether they are mutually
+// exclusive, and whether individual members require or conflict with one
+// another. Constraints are checked once Parse has finished matching flags.
+type Group struct {
+	name      string
+	opts      GroupOptions
+	members   []Arg
+	requires  map[Arg][]Arg
+	conflicts map[Arg][]Arg
+}
+
+// NewGroup creates a Group on this Command with the given name and options.
+func (o *Command) NewGroup(name string, opts GroupOptions) *Group {
+	g := &Group{
+		name:      name,
+		opts:      opts,
+		requires:  make(map[Arg][]Arg),
+		conflicts: make(map[Arg][]Arg),
+	}
+	o.groups = append(o.groups, g)
+	return g
+}
+
+// Add enrolls an existing Arg as a member of the group.
+func (g *Group) Add(a Arg) {
+	g.members = append(g.members, a)
+}
+
+// Requires declares that, if a was parsed, every arg in deps must also have
+// been parsed.
+func (g *Group) Requires(a Arg, deps ...Arg) {
+	g.requires[a] = append(g.requires[a], deps...)
+}
+
+// Conflicts declares that a and every arg in with cannot both be parsed.
+func (g *Group) Conflicts(a Arg, with ...Arg) {
+	g.conflicts[a] = append(g.conflicts[a], with...)
+}
+
+func isParsed(a Arg) bool {
+	underlying, ok := a.(*arg)
+	return ok && underlying.parsed
+}
+
+func argLabel(a Arg) string {
+	if underlying, ok := a.(*arg); ok {
+		return underlying.name()
+	}
+	return a.GetLname()
+}
+
+func argLabels(args []Arg) []string {
+	labels := make([]string, 0, len(args))
+	for _, a := range args {
+		labels = append(labels, argLabel(a))
+	}
+	return labels
+}
+
+// validate checks all of the group's constraints against the current parsed
+// state of its members, returning the first violation found.
+func (g *Group) validate() error {
+	var present []Arg
+	for _, a := range g.members {
+		if isParsed(a) {
+			present = append(present, a)
+		}
+	}
+
+	if g.opts.Required && len(present) == 0 {
+		return fmt.Errorf("at least one of [%s] is required", strings.Join(argLabels(g.members), ", "))
+	}
+	if g.opts.MutuallyExclusive && len(present) > 1 {
+		return fmt.Errorf("[%s] are mutually exclusive", strings.Join(argLabels(present), ", "))
+	}
+
+	for a, deps := range g.requires {
+		if !isParsed(a) {
+			continue
+		}
+		for _, dep := range deps {
+			if !isParsed(dep) {
+				return fmt.Errorf("[%s] requires [%s]", argLabel(a), argLabel(dep))
+			}
+		}
+	}
+	for a, with := range g.conflicts {
+		if !isParsed(a) {
+			continue
+		}
+		for _, other := range with {
+			if isParsed(other) {
+				return fmt.Errorf("[%s] conflicts with [%s]", argLabel(a), argLabel(other))
+			}
+		}
+	}
+
+	return nil
+}
+
+// usage renders the group in place of its members' own usage text in a
+// Command's summary usage line. Mutually exclusive groups are rendered as
+// "{--a | --b}"; Required-only groups (at least one member, not exclusive)
+// are rendered as "(--a | --b)"; plain groups fall back to their members'
+// names space-joined.
+func (g *Group) usage() string {
+	switch {
+	case g.opts.MutuallyExclusive:
+		return "{" + strings.Join(argLabels(g.members), " | ") + "}"
+	case g.opts.Required:
+		return "(" + strings.Join(argLabels(g.members), " | ") + ")"
+	default:
+		return strings.Join(argLabels(g.members), " ")
+	}
+}
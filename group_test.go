@@ -0,0 +1,47 @@
+package argparse
+
+import "testing"
+
+// TestGroupWithFlagConstructors covers building a mutually-exclusive group
+// out of ordinary Flag-backed args: --foo and --bar together are rejected,
+// while --foo alone is accepted.
+func TestGroupWithFlagConstructors(t *testing.T) {
+	p := NewParser("test", "")
+	_, fooArg := p.Flag("f", "foo", nil)
+	_, barArg := p.Flag("b", "bar", nil)
+
+	g := p.NewGroup("foobar", GroupOptions{MutuallyExclusive: true})
+	g.Add(fooArg)
+	g.Add(barArg)
+
+	if err := p.Parse([]string{"--foo", "--bar"}); err == nil {
+		t.Fatal("Parse() error = nil, want error for mutually exclusive --foo/--bar")
+	}
+
+	p = NewParser("test", "")
+	_, fooArg = p.Flag("f", "foo", nil)
+	_, barArg = p.Flag("b", "bar", nil)
+	g = p.NewGroup("foobar", GroupOptions{MutuallyExclusive: true})
+	g.Add(fooArg)
+	g.Add(barArg)
+
+	if err := p.Parse([]string{"--foo"}); err != nil {
+		t.Fatalf("Parse() error = %v, want nil for a single group member", err)
+	}
+}
+
+// TestGroupRequired covers a Required group where at least one member must
+// be present.
+func TestGroupRequired(t *testing.T) {
+	p := NewParser("test", "")
+	_, fooArg := p.Flag("f", "foo", nil)
+	_, barArg := p.Flag("b", "bar", nil)
+
+	g := p.NewGroup("foobar", GroupOptions{Required: true})
+	g.Add(fooArg)
+	g.Add(barArg)
+
+	if err := p.Parse(nil); err == nil {
+		t.Fatal("Parse() error = nil, want error when no required group member is present")
+	}
+}
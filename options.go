@@ -0,0 +1,28 @@
+package argparse
+
+// Options describes the optional settings that can be applied to an argument
+// when it is created on a Command.
+type Options struct {
+	Required bool                      // Required specifies whether the argument must be present
+	Validate func(args []string) error // Validate is run against the raw parsed tokens before assignment
+	Help     string                    // Help is the message shown for this argument in usage text
+	Default  interface{}               // Default is used when the argument was not present on the command line
+
+	// EnvVar, when set, is looked up with os.LookupEnv when the argument was
+	// not present on the command line. It takes precedence over a config-file
+	// value and over Default. For list-typed args, the value is split on
+	// EnvSeparator (or os.PathListSeparator if EnvSeparator is empty).
+	EnvVar       string
+	EnvSeparator string
+
+	// ConfigKey, when set (or else the argument's long name), is looked up in
+	// the Command's config after the environment variable and before Default.
+	// See Command.LoadConfig.
+	ConfigKey string
+
+	// Complete, when set, supplies dynamic shell completions for this
+	// argument's value (e.g. fetched from an API). It is invoked through the
+	// hidden --__complete flag handled by Command.Parse; see
+	// Parser.CompletionScript.
+	Complete func(prefix string) []string
+}
@@ -0,0 +1,138 @@
+package argparse
+
+import (
+	"fmt"
+	"os"
+)
+
+// Parse matches args against the arguments registered on this Command,
+// assigning parsed values and applying defaults. For each raw token, an
+// inline GNU-style assignment ("--long=value", "-sVALUE", "-s=value") is
+// checked first via arg.assignedValue so that it is consumed as a single
+// token; otherwise the token is matched and reduced using the existing
+// check/reduce pair, which also preserves shorthand grouping such as "-abc".
+// Everything after a bare "--" is never matched against flags and is forced
+// straight into the positional/remaining bucket. Once named flags have
+// claimed their tokens, whatever is left is handed to Positional,
+// PositionalList and RemainingArgs targets in that order; anything still
+// unclaimed is reported as an error. Finally, any Group registered on this
+// Command has its required/mutually-exclusive/dependent constraints checked.
+func (o *Command) Parse(args []string) error {
+	// Generated completion scripts call back into the binary with this
+	// hidden flag instead of going through normal parsing; see
+	// Parser.CompletionScript and Options.Complete.
+	if len(args) > 0 && args[0] == completeFlagName {
+		o.handleComplete(args[1:])
+		os.Exit(0)
+	}
+
+	tokens := append([]string{}, args...)
+	var forced []string
+	for i, t := range tokens {
+		if t == "--" {
+			forced = append(forced, tokens[i+1:]...)
+			tokens = tokens[:i]
+			break
+		}
+	}
+
+	for _, a := range o.args {
+		if a.positional {
+			continue
+		}
+
+		count := 0
+		for i := 0; i < len(tokens); i++ {
+			t := tokens[i]
+			if t == "" {
+				continue
+			}
+
+			if value, matched, err := a.assignedValue(t); matched {
+				if err != nil {
+					return err
+				}
+				count++
+				if err := a.parse([]string{value}, count); err != nil {
+					return err
+				}
+				tokens[i] = ""
+				continue
+			}
+
+			if n := a.check(t); n > 0 {
+				count += n
+				values := make([]string, 0, a.size-1)
+				for j := i + 1; j < i+a.size && j < len(tokens); j++ {
+					values = append(values, tokens[j])
+				}
+				if err := a.parse(values, n); err != nil {
+					return err
+				}
+				a.reduce(i, &tokens)
+			}
+		}
+
+		if a.opts != nil && a.opts.Required && !a.parsed {
+			return fmt.Errorf("[%s] is required", a.name())
+		}
+		if err := a.setDefault(); err != nil {
+			return err
+		}
+	}
+
+	positional := make([]string, 0, len(tokens)+len(forced))
+	for _, t := range tokens {
+		if t != "" {
+			positional = append(positional, t)
+		}
+	}
+	positional = append(positional, forced...)
+	o.argv = positional
+
+	for _, a := range o.positional {
+		if len(positional) == 0 {
+			break
+		}
+		if err := a.parse(positional[:1], 1); err != nil {
+			return err
+		}
+		positional = positional[1:]
+	}
+	for _, a := range o.positional {
+		if a.opts != nil && a.opts.Required && !a.parsed {
+			return fmt.Errorf("[%s] is required", a.name())
+		}
+		if err := a.setDefault(); err != nil {
+			return err
+		}
+	}
+
+	if o.positionalList != nil {
+		for i, v := range positional {
+			if err := o.positionalList.parse([]string{v}, i+1); err != nil {
+				return err
+			}
+		}
+		positional = nil
+		if err := o.positionalList.setDefault(); err != nil {
+			return err
+		}
+	}
+
+	if o.remaining != nil {
+		*o.remaining = append(*o.remaining, positional...)
+		positional = nil
+	}
+
+	if len(positional) > 0 {
+		return fmt.Errorf("unknown argument [%s]", positional[0])
+	}
+
+	for _, g := range o.groups {
+		if err := g.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package argparse
+
+// Positional registers a single positional argument, consumed in the order
+// its constructor was called, after all named flags have been matched.
+func (o *Command) Positional(name string, opts *Options) *string {
+	var result string
+	a := o.newArg(&result, "", "", 1, true, opts)
+	a.positional = true
+	a.varname = name
+	o.positional = append(o.positional, a)
+	return &result
+}
+
+// PositionalList registers a trailing positional argument that greedily
+// consumes every remaining positional token not claimed by a Positional. At
+// most one PositionalList may be registered on a Command.
+func (o *Command) PositionalList(name string, opts *Options) *[]string {
+	result := make([]string, 0)
+	a := o.newArg(&result, "", "", 1, false, opts)
+	a.positional = true
+	a.varname = name
+	o.positionalList = a
+	return &result
+}
+
+// RemainingArgs registers a target that receives every token left over once
+// flags and positionals have been matched, including everything after a "--"
+// sentinel, verbatim and without further parsing. At most one RemainingArgs
+// may be registered on a Command.
+func (o *Command) RemainingArgs() *[]string {
+	result := make([]string, 0)
+	o.remaining = &result
+	return &result
+}
+
+// NArg returns the number of positional tokens seen by the most recent Parse.
+func (o *Command) NArg() int {
+	return len(o.argv)
+}
+
+// Args returns the positional tokens seen by the most recent Parse, in the
+// order they appeared on the command line.
+func (o *Command) Args() []string {
+	return o.argv
+}
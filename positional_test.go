@@ -0,0 +1,31 @@
+package argparse
+
+import "testing"
+
+// TestPositionalDefault covers that an absent Positional falls through to
+// Options.Default, same as a named flag.
+func TestPositionalDefault(t *testing.T) {
+	p := NewParser("test", "")
+	name := p.Positional("name", &Options{Default: "fallback"})
+
+	if err := p.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if *name != "fallback" {
+		t.Errorf("*name = %q, want %q", *name, "fallback")
+	}
+}
+
+// TestPositionalListDefault covers that an empty PositionalList falls
+// through to Options.Default.
+func TestPositionalListDefault(t *testing.T) {
+	p := NewParser("test", "")
+	tags := p.PositionalList("tags", &Options{Default: []string{"a", "b"}})
+
+	if err := p.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(*tags) != 2 || (*tags)[0] != "a" || (*tags)[1] != "b" {
+		t.Errorf("*tags = %v, want [a b]", *tags)
+	}
+}
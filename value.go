@@ -0,0 +1,96 @@
+package argparse
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+)
+
+// Value is implemented by custom argument types. Set is called with the raw
+// token(s) collected for the argument (respecting its size), String renders
+// the current value as text (help text shows Options.Default as given, not
+// String), and Type names the value's kind for usage text, e.g. "duration"
+// or "ip".
+type Value interface {
+	Set(string) error
+	String() string
+	Type() string
+}
+
+// Var registers a custom Value-backed argument, for types not covered by the
+// built-in constructors. See Duration, IP and Regexp for examples built on
+// top of it.
+func (o *Command) Var(target Value, sname string, lname string, opts *Options) Arg {
+	return o.newArg(target, sname, lname, 2, true, opts)
+}
+
+type durationValue time.Duration
+
+func (d *durationValue) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(v)
+	return nil
+}
+func (d *durationValue) String() string { return time.Duration(*d).String() }
+func (d *durationValue) Type() string   { return "duration" }
+
+// Duration registers an argument parsed with time.ParseDuration, e.g. "5s" or "1h30m".
+func (o *Command) Duration(sname string, lname string, opts *Options) *time.Duration {
+	d := new(durationValue)
+	o.Var(d, sname, lname, opts)
+	return (*time.Duration)(d)
+}
+
+type ipValue struct{ ip *net.IP }
+
+func (v ipValue) Set(s string) error {
+	parsed := net.ParseIP(s)
+	if parsed == nil {
+		return fmt.Errorf("invalid IP address [%s]", s)
+	}
+	*v.ip = parsed
+	return nil
+}
+func (v ipValue) String() string {
+	if v.ip == nil || *v.ip == nil {
+		return ""
+	}
+	return v.ip.String()
+}
+func (v ipValue) Type() string { return "ip" }
+
+// IP registers an argument parsed with net.ParseIP.
+func (o *Command) IP(sname string, lname string, opts *Options) *net.IP {
+	var ip net.IP
+	o.Var(ipValue{ip: &ip}, sname, lname, opts)
+	return &ip
+}
+
+type regexpValue struct{ re **regexp.Regexp }
+
+func (v regexpValue) Set(s string) error {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return err
+	}
+	*v.re = re
+	return nil
+}
+func (v regexpValue) String() string {
+	if v.re == nil || *v.re == nil {
+		return ""
+	}
+	return (*v.re).String()
+}
+func (v regexpValue) Type() string { return "regexp" }
+
+// Regexp registers an argument parsed with regexp.Compile.
+func (o *Command) Regexp(sname string, lname string, opts *Options) **regexp.Regexp {
+	var re *regexp.Regexp
+	o.Var(regexpValue{re: &re}, sname, lname, opts)
+	return &re
+}
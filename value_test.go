@@ -0,0 +1,30 @@
+package argparse
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDuration covers the Value interface via the built-in Duration helper.
+func TestDuration(t *testing.T) {
+	p := NewParser("test", "")
+	d := p.Duration("t", "timeout", nil)
+
+	if err := p.Parse([]string{"--timeout=1h30m"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if *d != 90*time.Minute {
+		t.Errorf("*d = %v, want %v", *d, 90*time.Minute)
+	}
+}
+
+// TestDurationBadValue covers that an invalid duration is reported as a
+// parse error rather than silently ignored.
+func TestDurationBadValue(t *testing.T) {
+	p := NewParser("test", "")
+	p.Duration("t", "timeout", nil)
+
+	if err := p.Parse([]string{"--timeout=notaduration"}); err == nil {
+		t.Fatal("Parse() error = nil, want error for an invalid duration")
+	}
+}